@@ -0,0 +1,24 @@
+package log
+
+// ANSI 颜色转义序列，仅用于给终端输出的级别前缀上色。
+const (
+	colorReset  = "\x1b[0m"
+	colorCyan   = "\x1b[36m"
+	colorYellow = "\x1b[33m"
+	colorRed    = "\x1b[31m"
+)
+
+// levelColor 返回 level 对应的颜色前缀：DEBUG 为青色，WARN 为黄色，
+// ERROR/CRITICAL/PANIC/FATAL 为红色，其余级别不上色。
+func levelColor(level int) string {
+	switch level {
+	case LEVEL_DEBUG:
+		return colorCyan
+	case LEVEL_WARN:
+		return colorYellow
+	case LEVEL_ERROR, LEVEL_CRITICAL, LEVEL_PANIC, LEVEL_FATAL:
+		return colorRed
+	default:
+		return ""
+	}
+}
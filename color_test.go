@@ -0,0 +1,24 @@
+package log
+
+import "testing"
+
+func TestLevelColor(t *testing.T) {
+	red := []int{LEVEL_ERROR, LEVEL_CRITICAL, LEVEL_PANIC, LEVEL_FATAL}
+	for _, level := range red {
+		if got := levelColor(level); got != colorRed {
+			t.Errorf("levelColor(%d) = %q, want colorRed", level, got)
+		}
+	}
+
+	if got := levelColor(LEVEL_DEBUG); got != colorCyan {
+		t.Errorf("levelColor(LEVEL_DEBUG) = %q, want colorCyan", got)
+	}
+
+	if got := levelColor(LEVEL_WARN); got != colorYellow {
+		t.Errorf("levelColor(LEVEL_WARN) = %q, want colorYellow", got)
+	}
+
+	if got := levelColor(LEVEL_INFO); got != "" {
+		t.Errorf("levelColor(LEVEL_INFO) = %q, want no color", got)
+	}
+}
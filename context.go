@@ -0,0 +1,49 @@
+package log
+
+import "context"
+
+type entryContextKey struct{}
+
+// SpanContextExtractor 从 context.Context 中提取分布式追踪信息（trace_id、span_id），
+// 默认未设置、即不附加任何追踪字段。集成 OpenTelemetry 时可以在初始化处设置，
+// 避免本包直接依赖 go.opentelemetry.io/otel：
+//
+//	log.SpanContextExtractor = func(ctx context.Context) (traceID, spanID string, ok bool) {
+//	    sc := trace.SpanContextFromContext(ctx)
+//	    if !sc.IsValid() {
+//	        return "", "", false
+//	    }
+//	    return sc.TraceID().String(), sc.SpanID().String(), true
+//	}
+var SpanContextExtractor func(ctx context.Context) (traceID, spanID string, ok bool)
+
+// WithContext 返回一个绑定了 ctx 的 Entry：若 ctx 中已经通过 NewContext 存过 Entry，
+// 在其基础上继续累积字段（用于 HTTP/gRPC 中间件把 request-id 等字段一次性附加给
+// 下游代码复用）；否则从 l 新建一个 Entry。设置了 SpanContextExtractor 时，会
+// 自动附加 trace_id/span_id 字段。
+func (l *Logger) WithContext(ctx context.Context) *Entry {
+	entry := l.NewEntry()
+	if prev, ok := FromContext(ctx); ok {
+		entry = prev
+	}
+
+	if SpanContextExtractor != nil {
+		if traceID, spanID, ok := SpanContextExtractor(ctx); ok {
+			entry = entry.WithFields(Fields{"trace_id": traceID, "span_id": spanID})
+		}
+	}
+
+	return entry
+}
+
+// NewContext 返回一个携带 entry 的新 context.Context，供下游代码通过 FromContext 取回。
+func NewContext(ctx context.Context, entry *Entry) context.Context {
+	return context.WithValue(ctx, entryContextKey{}, entry)
+}
+
+// FromContext 取回此前通过 NewContext 存入 ctx 的 Entry。
+func FromContext(ctx context.Context) (*Entry, bool) {
+	entry, ok := ctx.Value(entryContextKey{}).(*Entry)
+
+	return entry, ok
+}
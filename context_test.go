@@ -0,0 +1,81 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+// TestLogger_WithContext_AccumulatesFields 验证 WithContext 会在已有 Entry 的基础上
+// 继续累积字段，而不是每次都从 Logger 重新开始。
+func TestLogger_WithContext_AccumulatesFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, "", 0)
+	l.SetFormatter(NewJSONFormatter())
+
+	ctx := NewContext(context.Background(), l.WithField("request_id", "abc123"))
+
+	l.WithContext(ctx).WithField("user", "alice").Info("handled request")
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &fields); err != nil {
+		t.Fatalf("unmarshal log line: %v (line=%q)", err, buf.String())
+	}
+
+	if fields["request_id"] != "abc123" {
+		t.Errorf("request_id = %v, want abc123", fields["request_id"])
+	}
+
+	if fields["user"] != "alice" {
+		t.Errorf("user = %v, want alice", fields["user"])
+	}
+}
+
+// TestLogger_WithContext_NoPriorEntry 验证 ctx 中没有存过 Entry 时，WithContext 从 l 新建一个。
+func TestLogger_WithContext_NoPriorEntry(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, "", 0)
+	l.SetFormatter(NewJSONFormatter())
+
+	l.WithContext(context.Background()).Info("hello")
+
+	if _, ok := FromContext(context.Background()); ok {
+		t.Fatalf("FromContext should report ok=false on a bare context.Background()")
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &fields); err != nil {
+		t.Fatalf("unmarshal log line: %v (line=%q)", err, buf.String())
+	}
+
+	if fields["message"] != "hello" {
+		t.Errorf("message = %v, want hello", fields["message"])
+	}
+}
+
+// TestLogger_WithContext_SpanContextExtractor 验证设置 SpanContextExtractor 后，
+// WithContext 会自动附加 trace_id/span_id 字段。
+func TestLogger_WithContext_SpanContextExtractor(t *testing.T) {
+	prev := SpanContextExtractor
+	defer func() { SpanContextExtractor = prev }()
+
+	SpanContextExtractor = func(ctx context.Context) (string, string, bool) {
+		return "trace-1", "span-1", true
+	}
+
+	var buf bytes.Buffer
+	l := New(&buf, "", 0)
+	l.SetFormatter(NewJSONFormatter())
+
+	l.WithContext(context.Background()).Info("traced")
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &fields); err != nil {
+		t.Fatalf("unmarshal log line: %v (line=%q)", err, buf.String())
+	}
+
+	if fields["trace_id"] != "trace-1" || fields["span_id"] != "span-1" {
+		t.Errorf("trace_id/span_id = %v/%v, want trace-1/span-1", fields["trace_id"], fields["span_id"])
+	}
+}
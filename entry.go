@@ -0,0 +1,195 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Entry 是一条正在构建中的日志记录，由 Logger.WithField/WithFields 产生，
+// 携带累积下来的结构化上下文，最终交给 Logger 的 Formatter 渲染输出。
+type Entry struct {
+	logger *Logger
+
+	// Fields 是随日志一同输出的结构化字段。
+	Fields Fields
+
+	// Time 是日志产生的时间。
+	Time time.Time
+
+	// Level 是本条日志的级别，取值为 LEVEL_* 常量之一。
+	Level int
+
+	// Message 是日志正文。
+	Message string
+
+	// Package、Function 记录调用方的包名和函数名，供 Formatter 使用。
+	Package  string
+	Function string
+}
+
+func newEntry(l *Logger) *Entry {
+	return &Entry{
+		logger: l,
+		Fields: make(Fields),
+	}
+}
+
+// WithField 返回一个携带了 key/value 字段的新 Entry，原 Entry 不受影响。
+func (e *Entry) WithField(key string, value interface{}) *Entry {
+	return e.WithFields(Fields{key: value})
+}
+
+// WithFields 返回一个合并了 fields 的新 Entry，原 Entry 不受影响。
+func (e *Entry) WithFields(fields Fields) *Entry {
+	data := make(Fields, len(e.Fields)+len(fields))
+	for k, v := range e.Fields {
+		data[k] = v
+	}
+	for k, v := range fields {
+		data[k] = v
+	}
+
+	return &Entry{
+		logger: e.logger,
+		Fields: data,
+	}
+}
+
+// caller 通过 runtime.Caller 解析出调用方所在的包名和函数名，填充 e.Package/e.Function。
+func (e *Entry) caller(calldepth int) {
+	pc, _, _, ok := runtime.Caller(calldepth)
+	if !ok {
+		return
+	}
+
+	pkgFunc := runtime.FuncForPC(pc).Name()
+	pos := strings.LastIndex(pkgFunc, ".")
+	if pos1 := strings.LastIndex(pkgFunc, ".("); pos1 != -1 {
+		pos = pos1
+	}
+
+	if pos == -1 {
+		e.Function = pkgFunc
+		return
+	}
+
+	e.Package, e.Function = pkgFunc[:pos], pkgFunc[pos+1:]
+}
+
+// log 按 calldepth 记录调用位置，套用 level 过滤后交给 Logger 完成格式化与写出。
+func (e *Entry) log(level int, calldepth int, msg string) {
+	if level&e.logger.Levels() == 0 {
+		return
+	}
+
+	e.Time = time.Now()
+	e.Level = level
+	e.Message = msg
+	e.caller(calldepth)
+
+	e.logger.write(e)
+
+	if level == LEVEL_PANIC {
+		panic(msg)
+	}
+}
+
+func (e *Entry) Debug(v ...interface{}) {
+	e.log(LEVEL_DEBUG, 3, fmt.Sprint(v...))
+}
+
+func (e *Entry) Debugln(v ...interface{}) {
+	e.log(LEVEL_DEBUG, 3, fmt.Sprintln(v...))
+}
+
+func (e *Entry) Debugf(format string, v ...interface{}) {
+	e.log(LEVEL_DEBUG, 3, fmt.Sprintf(format, v...))
+}
+
+func (e *Entry) Info(v ...interface{}) {
+	e.log(LEVEL_INFO, 3, fmt.Sprint(v...))
+}
+
+func (e *Entry) Infoln(v ...interface{}) {
+	e.log(LEVEL_INFO, 3, fmt.Sprintln(v...))
+}
+
+func (e *Entry) Infof(format string, v ...interface{}) {
+	e.log(LEVEL_INFO, 3, fmt.Sprintf(format, v...))
+}
+
+func (e *Entry) Warn(v ...interface{}) {
+	e.log(LEVEL_WARN, 3, fmt.Sprint(v...))
+}
+
+func (e *Entry) Warnln(v ...interface{}) {
+	e.log(LEVEL_WARN, 3, fmt.Sprintln(v...))
+}
+
+func (e *Entry) Warnf(format string, v ...interface{}) {
+	e.log(LEVEL_WARN, 3, fmt.Sprintf(format, v...))
+}
+
+func (e *Entry) Error(v ...interface{}) {
+	e.log(LEVEL_ERROR, 3, fmt.Sprint(v...))
+}
+
+func (e *Entry) Errorln(v ...interface{}) {
+	e.log(LEVEL_ERROR, 3, fmt.Sprintln(v...))
+}
+
+func (e *Entry) Errorf(format string, v ...interface{}) {
+	e.log(LEVEL_ERROR, 3, fmt.Sprintf(format, v...))
+}
+
+func (e *Entry) Critical(v ...interface{}) {
+	e.log(LEVEL_CRITICAL, 3, fmt.Sprint(v...))
+}
+
+func (e *Entry) Criticalln(v ...interface{}) {
+	e.log(LEVEL_CRITICAL, 3, fmt.Sprintln(v...))
+}
+
+func (e *Entry) Criticalf(format string, v ...interface{}) {
+	e.log(LEVEL_CRITICAL, 3, fmt.Sprintf(format, v...))
+}
+
+func (e *Entry) Panic(v ...interface{}) {
+	e.log(LEVEL_PANIC, 3, fmt.Sprint(v...))
+}
+
+func (e *Entry) Panicln(v ...interface{}) {
+	e.log(LEVEL_PANIC, 3, fmt.Sprintln(v...))
+}
+
+func (e *Entry) Panicf(format string, v ...interface{}) {
+	e.log(LEVEL_PANIC, 3, fmt.Sprintf(format, v...))
+}
+
+// fatal 和 log 类似，但不受 Logger.SetLevel 限制——Fatal* 总是记录并以 os.Exit(1)
+// 结束进程，这与 Logger.Fatal 一贯的语义保持一致。
+func (e *Entry) fatal(calldepth int, msg string) {
+	e.Time = time.Now()
+	e.Level = LEVEL_FATAL
+	e.Message = msg
+	e.caller(calldepth)
+
+	e.logger.write(e)
+
+	os.Exit(1)
+}
+
+func (e *Entry) Fatal(v ...interface{}) {
+	e.fatal(3, fmt.Sprint(v...))
+}
+
+func (e *Entry) Fatalln(v ...interface{}) {
+	e.fatal(3, fmt.Sprintln(v...))
+}
+
+func (e *Entry) Fatalf(format string, v ...interface{}) {
+	e.fatal(3, fmt.Sprintf(format, v...))
+}
@@ -0,0 +1,97 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func callSite(l *Logger) {
+	l.Debug("hello")
+}
+
+// TestEntry_CallerReportsCallSite 验证 Entry.caller 解析出的 package/function
+// 指向真正调用 Logger.Debug/Info/... 的代码，而不是 log 包自己的内部帧。
+func TestEntry_CallerReportsCallSite(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, "", 0)
+	l.SetFormatter(NewJSONFormatter())
+
+	callSite(l)
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &fields); err != nil {
+		t.Fatalf("unmarshal log line: %v (line=%q)", err, buf.String())
+	}
+
+	if got := fields["function"]; got != "callSite" {
+		t.Errorf("function = %v, want callSite", got)
+	}
+
+	if got, _ := fields["package"].(string); !strings.HasSuffix(got, "log") {
+		t.Errorf("package = %v, want this package", got)
+	}
+}
+
+// TestLogger_LevelFiltering 验证 SetLevel 会屏蔽未命中的级别，但不影响已启用的级别。
+func TestLogger_LevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, "", 0)
+	l.SetFormatter(NewJSONFormatter())
+	l.SetLevel(LEVEL_WARN | LEVEL_ERROR)
+
+	l.Debug("suppressed")
+	if buf.Len() != 0 {
+		t.Fatalf("Debug should be suppressed, got %q", buf.String())
+	}
+
+	l.Error("kept")
+	if !strings.Contains(buf.String(), "kept") {
+		t.Fatalf("Error should be logged, got %q", buf.String())
+	}
+}
+
+// TestLogger_Fatal_FiresHooksBeforeExit 在子进程中调用 Logger.Fatal，确认它
+// 经由 Entry/write 管线触发 Hook 之后才 os.Exit(1)，而不是走旧的 print 字符串路径。
+func TestLogger_Fatal_FiresHooksBeforeExit(t *testing.T) {
+	if os.Getenv("LOG_TEST_FATAL_CHILD") == "1" {
+		out, err := os.OpenFile(os.Getenv("LOG_TEST_FATAL_OUT"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			os.Exit(2)
+		}
+		defer out.Close()
+
+		l := New(os.Stderr, "", 0)
+		l.SetFormatter(NewJSONFormatter())
+		l.AddHook(NewFileHook(out, NewJSONFormatter()))
+
+		l.Fatal("boom")
+		return
+	}
+
+	outPath := t.TempDir() + "/fatal-hook.log"
+
+	cmd := exec.Command(os.Args[0], "-test.run", "TestLogger_Fatal_FiresHooksBeforeExit")
+	cmd.Env = append(os.Environ(), "LOG_TEST_FATAL_CHILD=1", "LOG_TEST_FATAL_OUT="+outPath)
+
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+		t.Fatalf("expected child to exit with status 1, got err=%v", err)
+	}
+
+	b, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read hook output: %v", err)
+	}
+
+	if !strings.Contains(string(b), "boom") {
+		t.Fatalf("hook output missing fatal message: %q", string(b))
+	}
+
+	if !strings.Contains(string(b), `"level":"[FATAL]"`) {
+		t.Fatalf("hook output missing fatal level: %q", string(b))
+	}
+}
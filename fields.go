@@ -0,0 +1,18 @@
+package log
+
+import "sort"
+
+// Fields 是一组结构化的键值对，可通过 WithField/WithFields 附加到日志条目上，
+// 最终由 Formatter 渲染进最终输出（JSON 的字段、logfmt 的 key=value 等）。
+type Fields map[string]interface{}
+
+// sortedKeys 返回 Fields 按字典序排列的键，保证同一条日志每次输出的字段顺序一致。
+func sortedKeys(fields Fields) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,278 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// redactedValue 替换命中 FilterKey/FilterValue 规则的字段值和消息内容。
+const redactedValue = "***"
+
+// FilterOption 配置 Filter 的脱敏/丢弃规则，由 FilterKey/FilterValue/FilterFunc/FilterLevel 构造。
+type FilterOption func(*Filter)
+
+// FilterKey 把结构化字段中 key（大小写不敏感）匹配以下任一名字的值替换为 "***"。
+func FilterKey(keys ...string) FilterOption {
+	return func(f *Filter) {
+		for _, k := range keys {
+			f.keys[strings.ToLower(k)] = struct{}{}
+		}
+	}
+}
+
+// FilterValue 把字段值或消息中包含以下任一子串的内容整体替换为 "***"。
+func FilterValue(values ...string) FilterOption {
+	return func(f *Filter) {
+		f.values = append(f.values, values...)
+	}
+}
+
+// FilterFunc 是自定义断言；对某条 Entry 返回 true 时该条日志被整体丢弃。
+func FilterFunc(fn func(entry *Entry) bool) FilterOption {
+	return func(f *Filter) {
+		f.funcs = append(f.funcs, fn)
+	}
+}
+
+// FilterLevel 丢弃级别低于 level 的 Entry。
+func FilterLevel(level int) FilterOption {
+	return func(f *Filter) {
+		f.minLevel = level
+	}
+}
+
+// Filter 包装一个 Logger，在结构化日志到达底层 Formatter/Hook 之前按配置规则脱敏
+// 或丢弃 Entry，用于防止密码、token 等敏感信息泄漏到最终的日志 sink。只有经由
+// Filter 的分级方法（Debug/Info/.../Fatal）及其 WithField/WithFields 发出的日志
+// 才会被过滤，直接调用被包装的 Logger 不受影响；从嵌入的 *Logger 继承来的
+// Print/Println/Printf、NewEntry 和 WithContext 不经过这些规则。
+type Filter struct {
+	*Logger
+
+	keys     map[string]struct{}
+	values   []string
+	funcs    []func(entry *Entry) bool
+	minLevel int
+}
+
+// NewFilter 返回一个包装了 l 的 Filter，按 opts 配置脱敏/丢弃规则。
+func NewFilter(l *Logger, opts ...FilterOption) *Filter {
+	f := &Filter{
+		Logger: l,
+		keys:   make(map[string]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+func (f *Filter) drop(entry *Entry) bool {
+	if f.minLevel != 0 && entry.Level < f.minLevel {
+		return true
+	}
+
+	for _, fn := range f.funcs {
+		if fn(entry) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *Filter) matchesValue(s string) bool {
+	for _, v := range f.values {
+		if strings.Contains(s, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redact 返回 entry 的一份副本，其中命中 FilterKey/FilterValue 规则的字段值和
+// 消息已被替换为 "***"。
+func (f *Filter) redact(entry *Entry) *Entry {
+	redacted := *entry
+
+	if len(entry.Fields) > 0 {
+		fields := make(Fields, len(entry.Fields))
+		for k, v := range entry.Fields {
+			_, byKey := f.keys[strings.ToLower(k)]
+			if byKey || f.matchesValue(fmt.Sprint(v)) {
+				fields[k] = redactedValue
+			} else {
+				fields[k] = v
+			}
+		}
+		redacted.Fields = fields
+	}
+
+	if f.matchesValue(redacted.Message) {
+		redacted.Message = redactedValue
+	}
+
+	return &redacted
+}
+
+// emit 套用丢弃与脱敏规则后，把 entry 交给被包装的 Logger 完成格式化、Hook 触发与写出；
+// 和 Entry.log 一样，LEVEL_PANIC 在写出之后会 panic(msg)。
+func (f *Filter) emit(entry *Entry, level int, msg string) {
+	entry.Time = time.Now()
+	entry.Level = level
+	entry.Message = msg
+	entry.caller(3)
+
+	if level&f.Logger.Levels() == 0 {
+		return
+	}
+
+	if f.drop(entry) {
+		return
+	}
+
+	f.Logger.write(f.redact(entry))
+
+	if level == LEVEL_PANIC {
+		panic(msg)
+	}
+}
+
+// fatal 和 emit 类似，但不受级别/丢弃规则限制——Fatal* 总是脱敏后记录并以
+// os.Exit(1) 结束进程，这与 Entry.fatal、Logger.Fatal 一贯的语义保持一致。
+func (f *Filter) fatal(entry *Entry, msg string) {
+	entry.Time = time.Now()
+	entry.Level = LEVEL_FATAL
+	entry.Message = msg
+	entry.caller(3)
+
+	f.Logger.write(f.redact(entry))
+
+	os.Exit(1)
+}
+
+func (f *Filter) Debug(v ...interface{})   { f.emit(f.NewEntry(), LEVEL_DEBUG, fmt.Sprint(v...)) }
+func (f *Filter) Debugln(v ...interface{}) { f.emit(f.NewEntry(), LEVEL_DEBUG, fmt.Sprintln(v...)) }
+func (f *Filter) Debugf(format string, v ...interface{}) {
+	f.emit(f.NewEntry(), LEVEL_DEBUG, fmt.Sprintf(format, v...))
+}
+func (f *Filter) Info(v ...interface{})   { f.emit(f.NewEntry(), LEVEL_INFO, fmt.Sprint(v...)) }
+func (f *Filter) Infoln(v ...interface{}) { f.emit(f.NewEntry(), LEVEL_INFO, fmt.Sprintln(v...)) }
+func (f *Filter) Infof(format string, v ...interface{}) {
+	f.emit(f.NewEntry(), LEVEL_INFO, fmt.Sprintf(format, v...))
+}
+func (f *Filter) Warn(v ...interface{})   { f.emit(f.NewEntry(), LEVEL_WARN, fmt.Sprint(v...)) }
+func (f *Filter) Warnln(v ...interface{}) { f.emit(f.NewEntry(), LEVEL_WARN, fmt.Sprintln(v...)) }
+func (f *Filter) Warnf(format string, v ...interface{}) {
+	f.emit(f.NewEntry(), LEVEL_WARN, fmt.Sprintf(format, v...))
+}
+func (f *Filter) Error(v ...interface{})   { f.emit(f.NewEntry(), LEVEL_ERROR, fmt.Sprint(v...)) }
+func (f *Filter) Errorln(v ...interface{}) { f.emit(f.NewEntry(), LEVEL_ERROR, fmt.Sprintln(v...)) }
+func (f *Filter) Errorf(format string, v ...interface{}) {
+	f.emit(f.NewEntry(), LEVEL_ERROR, fmt.Sprintf(format, v...))
+}
+func (f *Filter) Critical(v ...interface{}) { f.emit(f.NewEntry(), LEVEL_CRITICAL, fmt.Sprint(v...)) }
+func (f *Filter) Criticalln(v ...interface{}) {
+	f.emit(f.NewEntry(), LEVEL_CRITICAL, fmt.Sprintln(v...))
+}
+func (f *Filter) Criticalf(format string, v ...interface{}) {
+	f.emit(f.NewEntry(), LEVEL_CRITICAL, fmt.Sprintf(format, v...))
+}
+func (f *Filter) Panic(v ...interface{})   { f.emit(f.NewEntry(), LEVEL_PANIC, fmt.Sprint(v...)) }
+func (f *Filter) Panicln(v ...interface{}) { f.emit(f.NewEntry(), LEVEL_PANIC, fmt.Sprintln(v...)) }
+func (f *Filter) Panicf(format string, v ...interface{}) {
+	f.emit(f.NewEntry(), LEVEL_PANIC, fmt.Sprintf(format, v...))
+}
+func (f *Filter) Fatal(v ...interface{})   { f.fatal(f.NewEntry(), fmt.Sprint(v...)) }
+func (f *Filter) Fatalln(v ...interface{}) { f.fatal(f.NewEntry(), fmt.Sprintln(v...)) }
+func (f *Filter) Fatalf(format string, v ...interface{}) {
+	f.fatal(f.NewEntry(), fmt.Sprintf(format, v...))
+}
+
+// FilterEntry 是 Filter 版本的 Entry：WithField/WithFields 返回它而不是 *Entry，
+// 使后续的 Debug/Info/... 调用先经过 Filter 的脱敏/丢弃规则再写出。
+type FilterEntry struct {
+	filter *Filter
+	entry  *Entry
+}
+
+// WithField 返回一个携带了 key/value 字段、仍会经过 Filter 规则的 FilterEntry。
+func (f *Filter) WithField(key string, value interface{}) *FilterEntry {
+	return &FilterEntry{filter: f, entry: f.NewEntry().WithField(key, value)}
+}
+
+// WithFields 返回一个携带了 fields、仍会经过 Filter 规则的 FilterEntry。
+func (f *Filter) WithFields(fields Fields) *FilterEntry {
+	return &FilterEntry{filter: f, entry: f.NewEntry().WithFields(fields)}
+}
+
+func (fe *FilterEntry) WithField(key string, value interface{}) *FilterEntry {
+	return &FilterEntry{filter: fe.filter, entry: fe.entry.WithField(key, value)}
+}
+
+func (fe *FilterEntry) WithFields(fields Fields) *FilterEntry {
+	return &FilterEntry{filter: fe.filter, entry: fe.entry.WithFields(fields)}
+}
+
+func (fe *FilterEntry) Debug(v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_DEBUG, fmt.Sprint(v...))
+}
+func (fe *FilterEntry) Debugln(v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_DEBUG, fmt.Sprintln(v...))
+}
+func (fe *FilterEntry) Debugf(format string, v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_DEBUG, fmt.Sprintf(format, v...))
+}
+func (fe *FilterEntry) Info(v ...interface{}) { fe.filter.emit(fe.entry, LEVEL_INFO, fmt.Sprint(v...)) }
+func (fe *FilterEntry) Infoln(v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_INFO, fmt.Sprintln(v...))
+}
+func (fe *FilterEntry) Infof(format string, v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_INFO, fmt.Sprintf(format, v...))
+}
+func (fe *FilterEntry) Warn(v ...interface{}) { fe.filter.emit(fe.entry, LEVEL_WARN, fmt.Sprint(v...)) }
+func (fe *FilterEntry) Warnln(v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_WARN, fmt.Sprintln(v...))
+}
+func (fe *FilterEntry) Warnf(format string, v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_WARN, fmt.Sprintf(format, v...))
+}
+func (fe *FilterEntry) Error(v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_ERROR, fmt.Sprint(v...))
+}
+func (fe *FilterEntry) Errorln(v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_ERROR, fmt.Sprintln(v...))
+}
+func (fe *FilterEntry) Errorf(format string, v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_ERROR, fmt.Sprintf(format, v...))
+}
+func (fe *FilterEntry) Critical(v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_CRITICAL, fmt.Sprint(v...))
+}
+func (fe *FilterEntry) Criticalln(v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_CRITICAL, fmt.Sprintln(v...))
+}
+func (fe *FilterEntry) Criticalf(format string, v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_CRITICAL, fmt.Sprintf(format, v...))
+}
+func (fe *FilterEntry) Panic(v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_PANIC, fmt.Sprint(v...))
+}
+func (fe *FilterEntry) Panicln(v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_PANIC, fmt.Sprintln(v...))
+}
+func (fe *FilterEntry) Panicf(format string, v ...interface{}) {
+	fe.filter.emit(fe.entry, LEVEL_PANIC, fmt.Sprintf(format, v...))
+}
+func (fe *FilterEntry) Fatal(v ...interface{}) { fe.filter.fatal(fe.entry, fmt.Sprint(v...)) }
+func (fe *FilterEntry) Fatalln(v ...interface{}) {
+	fe.filter.fatal(fe.entry, fmt.Sprintln(v...))
+}
+func (fe *FilterEntry) Fatalf(format string, v ...interface{}) {
+	fe.filter.fatal(fe.entry, fmt.Sprintf(format, v...))
+}
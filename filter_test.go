@@ -0,0 +1,75 @@
+package log
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func recoverAndFormat(buf *bytes.Buffer, fn func()) (output, recovered string) {
+	defer func() {
+		output = buf.String()
+		if r := recover(); r != nil {
+			recovered = r.(string)
+		}
+	}()
+
+	fn()
+
+	return
+}
+
+// TestFilter_Panic_Redacts 验证 Filter.Panic 在 panic 之前已经先把敏感内容脱敏写出，
+// 而不是像旧版那样落到被嵌入的 Logger.Panic，绕开 redact。
+func TestFilter_Panic_Redacts(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, "", 0)
+	l.SetFormatter(NewJSONFormatter())
+	f := NewFilter(l, FilterValue("supersecret"))
+
+	output, recovered := recoverAndFormat(&buf, func() {
+		f.Panic("token=supersecret")
+	})
+
+	if recovered != "token=supersecret" {
+		t.Fatalf("recovered = %q, want original panic message", recovered)
+	}
+
+	if strings.Contains(output, "supersecret") {
+		t.Fatalf("secret leaked into sink: %q", output)
+	}
+
+	if !strings.Contains(output, redactedValue) {
+		t.Fatalf("expected redacted message in output, got %q", output)
+	}
+}
+
+// TestFilter_Fatal_Redacts 在子进程中调用 Filter.Fatal，确认退出前写出的日志已经脱敏。
+func TestFilter_Fatal_Redacts(t *testing.T) {
+	if os.Getenv("LOG_TEST_FILTER_FATAL_CHILD") == "1" {
+		l := New(os.Stdout, "", 0)
+		l.SetFormatter(NewJSONFormatter())
+		f := NewFilter(l, FilterValue("supersecret"))
+
+		f.Fatal("token=supersecret")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run", "TestFilter_Fatal_Redacts")
+	cmd.Env = append(os.Environ(), "LOG_TEST_FILTER_FATAL_CHILD=1")
+
+	out, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+		t.Fatalf("expected child to exit with status 1, got err=%v", err)
+	}
+
+	if strings.Contains(string(out), "supersecret") {
+		t.Fatalf("secret leaked into sink: %q", out)
+	}
+
+	if !strings.Contains(string(out), redactedValue) {
+		t.Fatalf("expected redacted message in output, got %q", out)
+	}
+}
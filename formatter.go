@@ -0,0 +1,221 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// defaultTimestampFormat 是未显式配置时间戳格式时使用的默认格式。
+const defaultTimestampFormat = "2006-01-02 15:04:05.000000"
+
+// Formatter 把一条 Entry 渲染为最终写入输出的字节流，用于在保留既有分级 API 的
+// 同时支持 JSON/logfmt 等机器可解析的输出格式。
+type Formatter interface {
+	Format(entry *Entry) ([]byte, error)
+}
+
+// TextFormatter 以既有的 "[LEVEL] [package] <function> message" 风格输出，
+// 并把结构化字段以 key=value 的形式追加在消息之后。
+type TextFormatter struct {
+	// TimestampFormat 指定时间戳的格式，为空时使用 defaultTimestampFormat。
+	TimestampFormat string
+
+	// DisableTimestamp 关闭时间戳输出。
+	DisableTimestamp bool
+
+	// ForceColors 强制开启颜色输出，即使输出不是终端。
+	ForceColors bool
+
+	// DisableColors 强制关闭颜色输出，优先级高于 ForceColors 和 EnvironmentOverrideColors。
+	DisableColors bool
+
+	// EnvironmentOverrideColors 为 true 时，环境变量 LOG_FORCE_COLORS/LOG_NO_COLORS
+	// 的取值会覆盖 ForceColors 以及终端探测的结果；DisableColors 仍然优先。
+	EnvironmentOverrideColors bool
+}
+
+// NewTextFormatter 返回一个使用默认时间戳格式的 TextFormatter。
+func NewTextFormatter() *TextFormatter {
+	return &TextFormatter{}
+}
+
+func (f *TextFormatter) timestampFormat() string {
+	if f.TimestampFormat == "" {
+		return defaultTimestampFormat
+	}
+
+	return f.TimestampFormat
+}
+
+// useColors 决定本次 Format 是否应该给级别前缀上色：DisableColors 优先关闭；
+// EnvironmentOverrideColors 打开时环境变量优先；否则 ForceColors 或输出本身是
+// 终端时才上色。
+func (f *TextFormatter) useColors(entry *Entry) bool {
+	if f.DisableColors {
+		return false
+	}
+
+	if f.EnvironmentOverrideColors {
+		switch {
+		case os.Getenv("LOG_FORCE_COLORS") != "":
+			return true
+		case os.Getenv("LOG_NO_COLORS") != "":
+			return false
+		}
+	}
+
+	if f.ForceColors {
+		return true
+	}
+
+	if entry.logger == nil {
+		return false
+	}
+
+	if out, ok := entry.logger.Writer().(*os.File); ok {
+		return isTerminal(out)
+	}
+
+	return false
+}
+
+func (f *TextFormatter) Format(entry *Entry) ([]byte, error) {
+	var b bytes.Buffer
+
+	if !f.DisableTimestamp {
+		b.WriteString(entry.Time.Format(f.timestampFormat()))
+		b.WriteByte(' ')
+	}
+
+	levelText := logPrefixs[entry.Level]
+	if f.useColors(entry) {
+		b.WriteString(levelColor(entry.Level))
+		b.WriteString(levelText)
+		b.WriteString(colorReset)
+	} else {
+		b.WriteString(levelText)
+	}
+	b.WriteByte(' ')
+
+	if entry.Package != "" {
+		b.WriteByte('[')
+		b.WriteString(entry.Package)
+		b.WriteString("] ")
+	}
+
+	if entry.Function != "" {
+		b.WriteByte('<')
+		b.WriteString(entry.Function)
+		b.WriteString("> ")
+	}
+
+	b.WriteString(entry.Message)
+
+	for _, k := range sortedKeys(entry.Fields) {
+		fmt.Fprintf(&b, " %s=%v", k, entry.Fields[k])
+	}
+
+	if b.Len() == 0 || b.Bytes()[b.Len()-1] != '\n' {
+		b.WriteByte('\n')
+	}
+
+	return b.Bytes(), nil
+}
+
+// JSONFormatter 把 Entry 渲染为单行 JSON，便于 ELK/Loki 等系统直接解析。
+type JSONFormatter struct {
+	// TimestampFormat 指定 time 字段的格式，为空时使用 defaultTimestampFormat。
+	TimestampFormat string
+}
+
+// NewJSONFormatter 返回一个使用默认时间戳格式的 JSONFormatter。
+func NewJSONFormatter() *JSONFormatter {
+	return &JSONFormatter{}
+}
+
+func (f *JSONFormatter) Format(entry *Entry) ([]byte, error) {
+	timestampFormat := f.TimestampFormat
+	if timestampFormat == "" {
+		timestampFormat = defaultTimestampFormat
+	}
+
+	data := make(map[string]interface{}, len(entry.Fields)+5)
+	for k, v := range entry.Fields {
+		data[k] = v
+	}
+
+	data["time"] = entry.Time.Format(timestampFormat)
+	data["level"] = logPrefixs[entry.Level]
+	data["message"] = entry.Message
+
+	if entry.Package != "" {
+		data["package"] = entry.Package
+	}
+	if entry.Function != "" {
+		data["function"] = entry.Function
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
+
+// LogfmtFormatter 把 Entry 渲染为 Heroku/Prometheus 风格的 logfmt（key=value 以空格分隔）。
+type LogfmtFormatter struct {
+	// TimestampFormat 指定 time 字段的格式，为空时使用 defaultTimestampFormat。
+	TimestampFormat string
+}
+
+// NewLogfmtFormatter 返回一个使用默认时间戳格式的 LogfmtFormatter。
+func NewLogfmtFormatter() *LogfmtFormatter {
+	return &LogfmtFormatter{}
+}
+
+func (f *LogfmtFormatter) Format(entry *Entry) ([]byte, error) {
+	timestampFormat := f.TimestampFormat
+	if timestampFormat == "" {
+		timestampFormat = defaultTimestampFormat
+	}
+
+	var b bytes.Buffer
+	writeLogfmtPair(&b, "time", entry.Time.Format(timestampFormat))
+	writeLogfmtPair(&b, "level", logPrefixs[entry.Level])
+
+	if entry.Package != "" {
+		writeLogfmtPair(&b, "package", entry.Package)
+	}
+	if entry.Function != "" {
+		writeLogfmtPair(&b, "function", entry.Function)
+	}
+
+	writeLogfmtPair(&b, "message", entry.Message)
+
+	for _, k := range sortedKeys(entry.Fields) {
+		writeLogfmtPair(&b, k, entry.Fields[k])
+	}
+
+	b.WriteByte('\n')
+
+	return b.Bytes(), nil
+}
+
+func writeLogfmtPair(b *bytes.Buffer, key string, value interface{}) {
+	if b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+
+	b.WriteString(key)
+	b.WriteByte('=')
+
+	s := fmt.Sprint(value)
+	if s == "" || bytes.ContainsAny([]byte(s), " =\"") {
+		b.WriteString(fmt.Sprintf("%q", s))
+	} else {
+		b.WriteString(s)
+	}
+}
@@ -0,0 +1,41 @@
+package log
+
+import (
+	"fmt"
+	"os"
+)
+
+// Hook 在一条结构化日志完成格式化后介入，用于把它转发到外部的副作用系统
+// （告警、Sentry、Kafka、Elasticsearch 等），不影响该日志是否被写入主输出。
+type Hook interface {
+	// Levels 返回该 Hook 关心的级别集合（LEVEL_* 按位或）。
+	Levels() int
+
+	// Fire 在 entry.Level 匹配 Levels() 时被调用；entry 已完成字段填充，只读即可。
+	Fire(entry *Entry) error
+}
+
+// AddHook 注册一个 Hook，write 每次写出 Entry 时都会检查并触发它。
+func (l *Logger) AddHook(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook)
+}
+
+// fireHooks 触发所有 Levels() 与 entry.Level 匹配的 Hook；单个 Hook 出错不影响其它 Hook
+// 或主输出，错误直接打印到 stderr，避免在日志路径上再产生递归日志。
+func (l *Logger) fireHooks(entry *Entry) {
+	l.mu.Lock()
+	hooks := l.hooks
+	l.mu.Unlock()
+
+	for _, hook := range hooks {
+		if hook.Levels()&entry.Level == 0 {
+			continue
+		}
+
+		if err := hook.Fire(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "log: hook error: %v\n", err)
+		}
+	}
+}
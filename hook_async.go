@@ -0,0 +1,46 @@
+package log
+
+// AsyncHook 把匹配级别的 Entry 异步投递到一个带缓冲的 channel，由调用方在独立的
+// goroutine 中消费并转发给 Sentry/Kafka/Elasticsearch 等外部系统，避免日志调用
+// 因下游处理慢而被阻塞。
+type AsyncHook struct {
+	levels  int
+	entries chan *Entry
+}
+
+// NewAsyncHook 创建一个容量为 bufferSize 的 AsyncHook，调用方通过 Entries() 消费。
+func NewAsyncHook(bufferSize int) *AsyncHook {
+	return &AsyncHook{
+		levels:  LEVEL_ALL,
+		entries: make(chan *Entry, bufferSize),
+	}
+}
+
+// SetLevels 限定该 Hook 只对指定级别生效。
+func (h *AsyncHook) SetLevels(levels int) {
+	h.levels = levels
+}
+
+func (h *AsyncHook) Levels() int {
+	return h.levels
+}
+
+// Entries 返回只读 channel，供消费者 goroutine 读取被 Fire 的 Entry。
+func (h *AsyncHook) Entries() <-chan *Entry {
+	return h.entries
+}
+
+// Fire 尝试把 entry 放入缓冲 channel；channel 已满时直接丢弃该条目，保证不阻塞调用方。
+func (h *AsyncHook) Fire(entry *Entry) error {
+	select {
+	case h.entries <- entry:
+	default:
+	}
+
+	return nil
+}
+
+// Close 关闭 entries channel，消费者可据此判断不会再有新日志到来。
+func (h *AsyncHook) Close() {
+	close(h.entries)
+}
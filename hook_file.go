@@ -0,0 +1,54 @@
+package log
+
+import (
+	"io"
+	"sync"
+)
+
+// FileHook 把匹配级别的 Entry 额外格式化并写入任意 io.Writer（通常是一个文件，
+// 也可以是 RotatingFileWriter），用于在不改变主输出的情况下把日志再落一份盘。
+type FileHook struct {
+	mu        sync.Mutex
+	levels    int
+	writer    io.Writer
+	formatter Formatter
+}
+
+// NewFileHook 返回一个把 LEVEL_ALL 的 Entry 写入 w 的 FileHook；formatter 为 nil 时使用 TextFormatter。
+func NewFileHook(w io.Writer, formatter Formatter) *FileHook {
+	if formatter == nil {
+		formatter = NewTextFormatter()
+	}
+
+	return &FileHook{
+		levels:    LEVEL_ALL,
+		writer:    w,
+		formatter: formatter,
+	}
+}
+
+// SetLevels 限定该 Hook 只对指定级别生效。
+func (h *FileHook) SetLevels(levels int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.levels = levels
+}
+
+func (h *FileHook) Levels() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.levels
+}
+
+func (h *FileHook) Fire(entry *Entry) error {
+	b, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err = h.writer.Write(b)
+
+	return err
+}
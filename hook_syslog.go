@@ -0,0 +1,62 @@
+//go:build !windows
+
+package log
+
+import "log/syslog"
+
+// syslogWriter 是 *syslog.Writer 用到的那部分方法，单独抽出来是为了在测试里
+// 不依赖真实的 syslog 守护进程也能验证 Fire 的级别映射。
+type syslogWriter interface {
+	Debug(m string) error
+	Info(m string) error
+	Warning(m string) error
+	Err(m string) error
+	Crit(m string) error
+	Emerg(m string) error
+}
+
+// SyslogHook 把匹配级别的 Entry 转发给本地或远程的 syslog 守护进程。
+type SyslogHook struct {
+	levels int
+	writer syslogWriter
+}
+
+// NewSyslogHook 拨号连接 syslog（network/raddr 为空时连接本机 syslog），
+// priority/tag 含义与 syslog.Dial 一致；syslog.LOG_ERR 等常量需先转换，
+// 如 SyslogPriority(syslog.LOG_ERR)。
+func NewSyslogHook(network, raddr string, priority SyslogPriority, tag string) (*SyslogHook, error) {
+	w, err := syslog.Dial(network, raddr, syslog.Priority(priority), tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SyslogHook{levels: LEVEL_ALL, writer: w}, nil
+}
+
+// SetLevels 限定该 Hook 只对指定级别生效。
+func (h *SyslogHook) SetLevels(levels int) {
+	h.levels = levels
+}
+
+func (h *SyslogHook) Levels() int {
+	return h.levels
+}
+
+func (h *SyslogHook) Fire(entry *Entry) error {
+	switch entry.Level {
+	case LEVEL_DEBUG:
+		return h.writer.Debug(entry.Message)
+	case LEVEL_INFO:
+		return h.writer.Info(entry.Message)
+	case LEVEL_WARN:
+		return h.writer.Warning(entry.Message)
+	case LEVEL_ERROR:
+		return h.writer.Err(entry.Message)
+	case LEVEL_CRITICAL, LEVEL_PANIC:
+		return h.writer.Crit(entry.Message)
+	case LEVEL_FATAL:
+		return h.writer.Emerg(entry.Message)
+	default:
+		return h.writer.Info(entry.Message)
+	}
+}
@@ -0,0 +1,49 @@
+//go:build !windows
+
+package log
+
+import "testing"
+
+type fakeSyslogWriter struct {
+	method  string
+	message string
+}
+
+func (w *fakeSyslogWriter) Debug(m string) error   { w.method, w.message = "debug", m; return nil }
+func (w *fakeSyslogWriter) Info(m string) error    { w.method, w.message = "info", m; return nil }
+func (w *fakeSyslogWriter) Warning(m string) error { w.method, w.message = "warning", m; return nil }
+func (w *fakeSyslogWriter) Err(m string) error     { w.method, w.message = "err", m; return nil }
+func (w *fakeSyslogWriter) Crit(m string) error    { w.method, w.message = "crit", m; return nil }
+func (w *fakeSyslogWriter) Emerg(m string) error   { w.method, w.message = "emerg", m; return nil }
+
+func TestSyslogHook_Fire_LevelMapping(t *testing.T) {
+	cases := []struct {
+		level int
+		want  string
+	}{
+		{LEVEL_DEBUG, "debug"},
+		{LEVEL_INFO, "info"},
+		{LEVEL_WARN, "warning"},
+		{LEVEL_ERROR, "err"},
+		{LEVEL_CRITICAL, "crit"},
+		{LEVEL_PANIC, "crit"},
+		{LEVEL_FATAL, "emerg"},
+	}
+
+	for _, c := range cases {
+		w := &fakeSyslogWriter{}
+		h := &SyslogHook{levels: LEVEL_ALL, writer: w}
+
+		if err := h.Fire(&Entry{Level: c.level, Message: "boom"}); err != nil {
+			t.Fatalf("level %d: Fire returned error: %v", c.level, err)
+		}
+
+		if w.method != c.want {
+			t.Errorf("level %d: dispatched to %q, want %q", c.level, w.method, c.want)
+		}
+
+		if w.message != "boom" {
+			t.Errorf("level %d: message = %q, want boom", c.level, w.message)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package log
+
+import "errors"
+
+// SyslogHook 在 Windows 上没有 log/syslog 可用，NewSyslogHook 始终返回 error，
+// 调用方应改用 FileHook 或事件日志等 Windows 原生机制。
+type SyslogHook struct{}
+
+func NewSyslogHook(network, raddr string, priority SyslogPriority, tag string) (*SyslogHook, error) {
+	return nil, errors.New("log: syslog hook is not supported on windows")
+}
+
+func (h *SyslogHook) SetLevels(levels int) {}
+
+func (h *SyslogHook) Levels() int { return LEVEL_DISABLE }
+
+func (h *SyslogHook) Fire(entry *Entry) error { return nil }
@@ -0,0 +1,19 @@
+//go:build linux
+
+package log
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// TCGETS 是 Linux 上用于探测文件描述符是否为终端的 ioctl 请求号。
+const ioctlGetTermios = 0x5401
+
+func isTerminal(f *os.File) bool {
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall6(syscall.SYS_IOCTL, f.Fd(), ioctlGetTermios, uintptr(unsafe.Pointer(&termios)), 0, 0, 0)
+
+	return errno == 0
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !windows
+
+package log
+
+import "os"
+
+// isTerminal 在未特别适配的平台上保守地认为输出不是终端，颜色输出会被跳过。
+func isTerminal(f *os.File) bool {
+	return false
+}
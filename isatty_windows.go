@@ -0,0 +1,38 @@
+//go:build windows
+
+package log
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32           = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleMode = kernel32.NewProc("GetConsoleMode")
+	procSetConsoleMode = kernel32.NewProc("SetConsoleMode")
+)
+
+// enableVirtualTerminalProcessing 对应 Windows 10+ 的 ENABLE_VIRTUAL_TERMINAL_PROCESSING，
+// 开启后 console 能正确渲染 ANSI 转义序列。
+const enableVirtualTerminalProcessing = 0x0004
+
+func isTerminal(f *os.File) bool {
+	var mode uint32
+	r, _, _ := procGetConsoleMode.Call(f.Fd(), uintptr(unsafe.Pointer(&mode)))
+	if r == 0 {
+		return false
+	}
+
+	return enableWindowsVirtualTerminal(f, mode)
+}
+
+// enableWindowsVirtualTerminal 尝试在该 console handle 上开启虚拟终端处理，返回是否成功。
+// 在 Windows 10 之前的版本上 SetConsoleMode 调用会失败，此时返回 false，
+// isTerminal 随之返回 false，调用方继续以无色模式输出。
+func enableWindowsVirtualTerminal(f *os.File, mode uint32) bool {
+	r, _, _ := procSetConsoleMode.Call(f.Fd(), uintptr(mode|enableVirtualTerminalProcessing))
+
+	return r != 0
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"os"
 	"runtime"
 	"strings"
 	"sync"
@@ -29,7 +28,7 @@ const (
 	Lproduction   = Ldate | Ltime | Lmicroseconds | Lpackage | Lfunction
 )
 
-//日志级别
+// 日志级别
 const (
 	LEVEL_DISABLE = 0 //关闭日志功能
 	LEVEL_DEBUG   = 1 << iota
@@ -38,11 +37,20 @@ const (
 	LEVEL_ERROR
 	LEVEL_CRITICAL
 	LEVEL_PANIC
+	// LEVEL_FATAL 和其它级别一样是一个可被 Hook.Levels() 匹配的位，但 Fatal* 系列
+	// 方法本身不受 SetLevel 限制——它们记录后总会 os.Exit(1)，这与 Logger.Fatal
+	// 一直以来的语义保持一致。
+	//
+	// 破坏性变更：LEVEL_FATAL 此前是导出的字符串常量（值为 "[FATAL]"），
+	// 为了让 Fatal 走和其它级别一样的 Entry/write/Hook 管线，这里把它改成了和
+	// LEVEL_DEBUG/.../LEVEL_PANIC 一致的 int 位标记。任何把 log.LEVEL_FATAL
+	// 当字符串用的下游代码（比如直接拼接到日志前缀里）在升级后都无法编译；
+	// 级别对应的 "[FATAL]" 前缀现在由 Formatter 渲染，不需要调用方自己拼接。
+	LEVEL_FATAL
 )
 
 const (
-	LEVEL_FATAL = "[FATAL]"
-	LEVEL_ALL   = LEVEL_DEBUG | LEVEL_INFO | LEVEL_WARN | LEVEL_ERROR | LEVEL_CRITICAL | LEVEL_PANIC
+	LEVEL_ALL = LEVEL_DEBUG | LEVEL_INFO | LEVEL_WARN | LEVEL_ERROR | LEVEL_CRITICAL | LEVEL_PANIC | LEVEL_FATAL
 
 	//默认日志级别为
 	LEVEL_DEFAULT = LEVEL_ALL
@@ -57,6 +65,7 @@ var (
 		"error":    LEVEL_ERROR,
 		"critical": LEVEL_CRITICAL,
 		"panic":    LEVEL_PANIC,
+		"fatal":    LEVEL_FATAL,
 		"all":      LEVEL_ALL,
 	}
 
@@ -67,13 +76,16 @@ var (
 		LEVEL_ERROR:    "[ERROR]",
 		LEVEL_CRITICAL: "[CRITICAL]",
 		LEVEL_PANIC:    "[PANIC]",
+		LEVEL_FATAL:    "[FATAL]",
 	}
 )
 
 type Logger struct {
 	*log.Logger
-	mu    sync.Mutex
-	level int
+	mu        sync.Mutex
+	level     int
+	formatter Formatter
+	hooks     []Hook
 }
 
 func New(out io.Writer, prefix string, flag int) *Logger {
@@ -88,13 +100,68 @@ func (l *Logger) SetOutput(w io.Writer) {
 	prefix := l.Prefix()
 	flags := l.Flags()
 	levels := l.Levels()
+	formatter := l.formatter
+	hooks := l.hooks
 	l.mu.Unlock()
 	*l = Logger{
-		Logger: log.New(w, prefix, flags),
-		level:  levels,
+		Logger:    log.New(w, prefix, flags),
+		level:     levels,
+		formatter: formatter,
+		hooks:     hooks,
 	}
 }
 
+// SetFormatter 设置结构化日志（WithField/WithFields 产生的 Entry）的输出格式，
+// 未设置时默认使用 TextFormatter。
+func (l *Logger) SetFormatter(formatter Formatter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.formatter = formatter
+}
+
+func (l *Logger) formatterOrDefault() Formatter {
+	l.mu.Lock()
+	formatter := l.formatter
+	l.mu.Unlock()
+	if formatter == nil {
+		return NewTextFormatter()
+	}
+
+	return formatter
+}
+
+// write 把 Entry 交给当前 Formatter 渲染，并把结果直接写入底层 io.Writer，
+// 不再经过 Print 系列使用的 SetPrefix 字符串拼接。
+func (l *Logger) write(e *Entry) error {
+	b, err := l.formatterOrDefault().Format(e)
+	if err != nil {
+		return err
+	}
+
+	l.fireHooks(e)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.Writer().Write(b)
+
+	return err
+}
+
+// NewEntry 返回一个绑定到本 Logger、尚未携带任何字段的 Entry。
+func (l *Logger) NewEntry() *Entry {
+	return newEntry(l)
+}
+
+// WithField 返回一个携带了 key/value 字段的 Entry，可继续调用 Debug/Info/Warn/... 输出。
+func (l *Logger) WithField(key string, value interface{}) *Entry {
+	return l.NewEntry().WithField(key, value)
+}
+
+// WithFields 返回一个携带了 fields 的 Entry，可继续调用 Debug/Info/Warn/... 输出。
+func (l *Logger) WithFields(fields Fields) *Entry {
+	return l.NewEntry().WithFields(fields)
+}
+
 func ParseLevel(level string) int {
 	if level == "" {
 		return LEVEL_DEFAULT
@@ -198,44 +265,93 @@ func (l *Logger) Printf(format string, v ...interface{}) {
 	l.printf("", format, v...)
 }
 
-func (l *Logger) Panic(v ...interface{}) {
-	if LEVEL_PANIC&l.level == 0 {
-		return
-	}
+// Panic/Panicln/Panicf、Fatal/Fatalln/Fatalf 都经由 Entry/write 这条结构化管线
+// 输出（而不是 print 系列的字符串前缀拼接），这样 Formatter 和 AddHook 注册的
+// 告警 Hook 对它们同样生效。
 
-	l.print(logPrefixs[LEVEL_PANIC]+" ", v...)
-	panic(fmt.Sprint(v...))
+func (l *Logger) Panic(v ...interface{}) {
+	l.NewEntry().log(LEVEL_PANIC, 3, fmt.Sprint(v...))
 }
 
 func (l *Logger) Panicln(v ...interface{}) {
-	if LEVEL_PANIC&l.level == 0 {
-		return
-	}
-
-	l.println(logPrefixs[LEVEL_PANIC]+" ", v...)
-	panic(fmt.Sprintln(v...))
+	l.NewEntry().log(LEVEL_PANIC, 3, fmt.Sprintln(v...))
 }
 
 func (l *Logger) Panicf(format string, v ...interface{}) {
-	if LEVEL_PANIC&l.level == 0 {
-		return
-	}
-
-	l.printf(logPrefixs[LEVEL_PANIC]+" ", format, v...)
-	panic(fmt.Sprintf(format, v...))
+	l.NewEntry().log(LEVEL_PANIC, 3, fmt.Sprintf(format, v...))
 }
 
 func (l *Logger) Fatal(v ...interface{}) {
-	l.print(LEVEL_FATAL+" ", v...)
-	os.Exit(1)
+	l.NewEntry().fatal(3, fmt.Sprint(v...))
 }
 
 func (l *Logger) Fatalln(v ...interface{}) {
-	l.println(LEVEL_FATAL+" ", v...)
-	os.Exit(1)
+	l.NewEntry().fatal(3, fmt.Sprintln(v...))
 }
 
 func (l *Logger) Fatalf(format string, v ...interface{}) {
-	l.printf(LEVEL_FATAL+" ", format, v...)
-	os.Exit(1)
+	l.NewEntry().fatal(3, fmt.Sprintf(format, v...))
+}
+
+// 以下是结构化日志的分级入口，等价于 l.NewEntry().Debug(...) 等，
+// 直接在 Logger 上调用时不携带任何 WithField/WithFields 字段。
+
+func (l *Logger) Debug(v ...interface{}) {
+	l.NewEntry().log(LEVEL_DEBUG, 3, fmt.Sprint(v...))
+}
+
+func (l *Logger) Debugln(v ...interface{}) {
+	l.NewEntry().log(LEVEL_DEBUG, 3, fmt.Sprintln(v...))
+}
+
+func (l *Logger) Debugf(format string, v ...interface{}) {
+	l.NewEntry().log(LEVEL_DEBUG, 3, fmt.Sprintf(format, v...))
+}
+
+func (l *Logger) Info(v ...interface{}) {
+	l.NewEntry().log(LEVEL_INFO, 3, fmt.Sprint(v...))
+}
+
+func (l *Logger) Infoln(v ...interface{}) {
+	l.NewEntry().log(LEVEL_INFO, 3, fmt.Sprintln(v...))
+}
+
+func (l *Logger) Infof(format string, v ...interface{}) {
+	l.NewEntry().log(LEVEL_INFO, 3, fmt.Sprintf(format, v...))
+}
+
+func (l *Logger) Warn(v ...interface{}) {
+	l.NewEntry().log(LEVEL_WARN, 3, fmt.Sprint(v...))
+}
+
+func (l *Logger) Warnln(v ...interface{}) {
+	l.NewEntry().log(LEVEL_WARN, 3, fmt.Sprintln(v...))
+}
+
+func (l *Logger) Warnf(format string, v ...interface{}) {
+	l.NewEntry().log(LEVEL_WARN, 3, fmt.Sprintf(format, v...))
+}
+
+func (l *Logger) Error(v ...interface{}) {
+	l.NewEntry().log(LEVEL_ERROR, 3, fmt.Sprint(v...))
+}
+
+func (l *Logger) Errorln(v ...interface{}) {
+	l.NewEntry().log(LEVEL_ERROR, 3, fmt.Sprintln(v...))
+}
+
+func (l *Logger) Errorf(format string, v ...interface{}) {
+	l.NewEntry().log(LEVEL_ERROR, 3, fmt.Sprintf(format, v...))
+}
+
+func (l *Logger) Critical(v ...interface{}) {
+	l.NewEntry().log(LEVEL_CRITICAL, 3, fmt.Sprint(v...))
+}
+
+func (l *Logger) Criticalln(v ...interface{}) {
+	l.NewEntry().log(LEVEL_CRITICAL, 3, fmt.Sprintln(v...))
+}
+
+func (l *Logger) Criticalf(format string, v ...interface{}) {
+	l.NewEntry().log(LEVEL_CRITICAL, 3, fmt.Sprintf(format, v...))
 }
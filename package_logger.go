@@ -0,0 +1,361 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// PackageLogger 是某个包专属的日志句柄，由 NewPackageLogger 创建。它只持有自己的
+// 级别；真正的输出（Writer/Formatter/Hook）共享同一个 RepoLogger 的 backend
+// *Logger，这样 RepoLogger.SetOutput/SetFormatter 可以一次性重新配置整个 repo
+// 下的所有包，而不必逐个替换 PackageLogger。
+type PackageLogger struct {
+	repo *RepoLogger
+	pkg  string
+
+	mu    sync.Mutex
+	level int
+}
+
+// Repo 返回该 PackageLogger 所属的 repo 名。
+func (pl *PackageLogger) Repo() string {
+	return pl.repo.repo
+}
+
+// Package 返回该 PackageLogger 对应的包名。
+func (pl *PackageLogger) Package() string {
+	return pl.pkg
+}
+
+// SetLevel 设置该包自己的级别，不影响同一 repo 下的其它包。
+func (pl *PackageLogger) SetLevel(level interface{}) {
+	switch v := level.(type) {
+	case int:
+		pl.mu.Lock()
+		defer pl.mu.Unlock()
+		pl.level = v
+	case string:
+		lv := ParseLevel(v)
+		pl.mu.Lock()
+		defer pl.mu.Unlock()
+		pl.level = lv
+	}
+}
+
+func (pl *PackageLogger) Levels() int {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+	return pl.level
+}
+
+// entry 返回一个绑定到共享 backend 的新 Entry；caller/formatter/hook 都发生在 backend 上。
+func (pl *PackageLogger) entry() *Entry {
+	return pl.repo.backend.NewEntry()
+}
+
+func (pl *PackageLogger) log(level int, calldepth int, msg string) {
+	if level&pl.Levels() == 0 {
+		return
+	}
+
+	pl.entry().log(level, calldepth, msg)
+}
+
+func (pl *PackageLogger) Debug(v ...interface{}) {
+	pl.log(LEVEL_DEBUG, 4, fmt.Sprint(v...))
+}
+
+func (pl *PackageLogger) Debugln(v ...interface{}) {
+	pl.log(LEVEL_DEBUG, 4, fmt.Sprintln(v...))
+}
+
+func (pl *PackageLogger) Debugf(format string, v ...interface{}) {
+	pl.log(LEVEL_DEBUG, 4, fmt.Sprintf(format, v...))
+}
+
+func (pl *PackageLogger) Info(v ...interface{}) {
+	pl.log(LEVEL_INFO, 4, fmt.Sprint(v...))
+}
+
+func (pl *PackageLogger) Infoln(v ...interface{}) {
+	pl.log(LEVEL_INFO, 4, fmt.Sprintln(v...))
+}
+
+func (pl *PackageLogger) Infof(format string, v ...interface{}) {
+	pl.log(LEVEL_INFO, 4, fmt.Sprintf(format, v...))
+}
+
+func (pl *PackageLogger) Warn(v ...interface{}) {
+	pl.log(LEVEL_WARN, 4, fmt.Sprint(v...))
+}
+
+func (pl *PackageLogger) Warnln(v ...interface{}) {
+	pl.log(LEVEL_WARN, 4, fmt.Sprintln(v...))
+}
+
+func (pl *PackageLogger) Warnf(format string, v ...interface{}) {
+	pl.log(LEVEL_WARN, 4, fmt.Sprintf(format, v...))
+}
+
+func (pl *PackageLogger) Error(v ...interface{}) {
+	pl.log(LEVEL_ERROR, 4, fmt.Sprint(v...))
+}
+
+func (pl *PackageLogger) Errorln(v ...interface{}) {
+	pl.log(LEVEL_ERROR, 4, fmt.Sprintln(v...))
+}
+
+func (pl *PackageLogger) Errorf(format string, v ...interface{}) {
+	pl.log(LEVEL_ERROR, 4, fmt.Sprintf(format, v...))
+}
+
+func (pl *PackageLogger) Critical(v ...interface{}) {
+	pl.log(LEVEL_CRITICAL, 4, fmt.Sprint(v...))
+}
+
+func (pl *PackageLogger) Criticalln(v ...interface{}) {
+	pl.log(LEVEL_CRITICAL, 4, fmt.Sprintln(v...))
+}
+
+func (pl *PackageLogger) Criticalf(format string, v ...interface{}) {
+	pl.log(LEVEL_CRITICAL, 4, fmt.Sprintf(format, v...))
+}
+
+func (pl *PackageLogger) Panic(v ...interface{}) {
+	pl.log(LEVEL_PANIC, 4, fmt.Sprint(v...))
+}
+
+func (pl *PackageLogger) Panicln(v ...interface{}) {
+	pl.log(LEVEL_PANIC, 4, fmt.Sprintln(v...))
+}
+
+func (pl *PackageLogger) Panicf(format string, v ...interface{}) {
+	pl.log(LEVEL_PANIC, 4, fmt.Sprintf(format, v...))
+}
+
+// Fatal/Fatalln/Fatalf 和 Logger.Fatal 一样，总是记录并以 os.Exit(1) 结束进程，
+// 不受该包自己的级别限制。
+func (pl *PackageLogger) Fatal(v ...interface{}) {
+	pl.entry().fatal(3, fmt.Sprint(v...))
+}
+
+func (pl *PackageLogger) Fatalln(v ...interface{}) {
+	pl.entry().fatal(3, fmt.Sprintln(v...))
+}
+
+func (pl *PackageLogger) Fatalf(format string, v ...interface{}) {
+	pl.entry().fatal(3, fmt.Sprintf(format, v...))
+}
+
+// WithField 返回一个携带了 key/value 字段、仍会经过本包级别过滤的 PackageEntry。
+func (pl *PackageLogger) WithField(key string, value interface{}) *PackageEntry {
+	return &PackageEntry{pl: pl, entry: pl.entry().WithField(key, value)}
+}
+
+// WithFields 返回一个携带了 fields、仍会经过本包级别过滤的 PackageEntry。
+func (pl *PackageLogger) WithFields(fields Fields) *PackageEntry {
+	return &PackageEntry{pl: pl, entry: pl.entry().WithFields(fields)}
+}
+
+// PackageEntry 是 PackageLogger 版本的 Entry：WithField/WithFields 返回它而不是
+// *Entry，使后续的 Debug/Info/... 调用仍然遵守该包自己的级别。
+type PackageEntry struct {
+	pl    *PackageLogger
+	entry *Entry
+}
+
+func (pe *PackageEntry) WithField(key string, value interface{}) *PackageEntry {
+	return &PackageEntry{pl: pe.pl, entry: pe.entry.WithField(key, value)}
+}
+
+func (pe *PackageEntry) WithFields(fields Fields) *PackageEntry {
+	return &PackageEntry{pl: pe.pl, entry: pe.entry.WithFields(fields)}
+}
+
+func (pe *PackageEntry) emit(level int, calldepth int, msg string) {
+	if level&pe.pl.Levels() == 0 {
+		return
+	}
+
+	pe.entry.log(level, calldepth, msg)
+}
+
+func (pe *PackageEntry) Debug(v ...interface{})   { pe.emit(LEVEL_DEBUG, 4, fmt.Sprint(v...)) }
+func (pe *PackageEntry) Debugln(v ...interface{}) { pe.emit(LEVEL_DEBUG, 4, fmt.Sprintln(v...)) }
+func (pe *PackageEntry) Debugf(format string, v ...interface{}) {
+	pe.emit(LEVEL_DEBUG, 4, fmt.Sprintf(format, v...))
+}
+func (pe *PackageEntry) Info(v ...interface{})   { pe.emit(LEVEL_INFO, 4, fmt.Sprint(v...)) }
+func (pe *PackageEntry) Infoln(v ...interface{}) { pe.emit(LEVEL_INFO, 4, fmt.Sprintln(v...)) }
+func (pe *PackageEntry) Infof(format string, v ...interface{}) {
+	pe.emit(LEVEL_INFO, 4, fmt.Sprintf(format, v...))
+}
+func (pe *PackageEntry) Warn(v ...interface{})   { pe.emit(LEVEL_WARN, 4, fmt.Sprint(v...)) }
+func (pe *PackageEntry) Warnln(v ...interface{}) { pe.emit(LEVEL_WARN, 4, fmt.Sprintln(v...)) }
+func (pe *PackageEntry) Warnf(format string, v ...interface{}) {
+	pe.emit(LEVEL_WARN, 4, fmt.Sprintf(format, v...))
+}
+func (pe *PackageEntry) Error(v ...interface{})   { pe.emit(LEVEL_ERROR, 4, fmt.Sprint(v...)) }
+func (pe *PackageEntry) Errorln(v ...interface{}) { pe.emit(LEVEL_ERROR, 4, fmt.Sprintln(v...)) }
+func (pe *PackageEntry) Errorf(format string, v ...interface{}) {
+	pe.emit(LEVEL_ERROR, 4, fmt.Sprintf(format, v...))
+}
+func (pe *PackageEntry) Critical(v ...interface{}) { pe.emit(LEVEL_CRITICAL, 4, fmt.Sprint(v...)) }
+func (pe *PackageEntry) Criticalln(v ...interface{}) {
+	pe.emit(LEVEL_CRITICAL, 4, fmt.Sprintln(v...))
+}
+func (pe *PackageEntry) Criticalf(format string, v ...interface{}) {
+	pe.emit(LEVEL_CRITICAL, 4, fmt.Sprintf(format, v...))
+}
+
+// Fatal/Fatalln/Fatalf 同样不受包级别限制，总是记录并退出进程。
+func (pe *PackageEntry) Fatal(v ...interface{})   { pe.entry.fatal(3, fmt.Sprint(v...)) }
+func (pe *PackageEntry) Fatalln(v ...interface{}) { pe.entry.fatal(3, fmt.Sprintln(v...)) }
+func (pe *PackageEntry) Fatalf(format string, v ...interface{}) {
+	pe.entry.fatal(3, fmt.Sprintf(format, v...))
+}
+
+// RepoLogger 管理同一个仓库（repo）下所有 PackageLogger 共享的 backend Logger：
+// SetOutput/SetFormatter 在这里重新配置一次，即可让该 repo 下的所有包同时生效；
+// SetLogLevel 则按包名批量调整各自独立的级别。
+type RepoLogger struct {
+	mu       sync.Mutex
+	repo     string
+	backend  *Logger
+	packages map[string]*PackageLogger
+}
+
+var (
+	repoMu      sync.Mutex
+	repoLoggers = make(map[string]*RepoLogger)
+)
+
+// NewPackageLogger 返回 repo 下 pkg 包专属的 Logger；以相同 repo/pkg 多次调用返回同一实例。
+func NewPackageLogger(repo, pkg string) *PackageLogger {
+	rl := repoLoggerFor(repo)
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if pl, ok := rl.packages[pkg]; ok {
+		return pl
+	}
+
+	pl := &PackageLogger{
+		repo:  rl,
+		pkg:   pkg,
+		level: LEVEL_DEFAULT,
+	}
+	rl.packages[pkg] = pl
+
+	return pl
+}
+
+func repoLoggerFor(repo string) *RepoLogger {
+	repoMu.Lock()
+	defer repoMu.Unlock()
+
+	rl, ok := repoLoggers[repo]
+	if !ok {
+		rl = &RepoLogger{
+			repo:     repo,
+			backend:  New(os.Stderr, "", LstdFlags),
+			packages: make(map[string]*PackageLogger),
+		}
+		repoLoggers[repo] = rl
+	}
+
+	return rl
+}
+
+// GetRepoLogger 返回 repo 对应的 RepoLogger；若该 repo 下还没有包通过
+// NewPackageLogger 注册过，返回 error。
+func GetRepoLogger(repo string) (*RepoLogger, error) {
+	repoMu.Lock()
+	rl, ok := repoLoggers[repo]
+	repoMu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("log: no packages registered for repo %q", repo)
+	}
+
+	return rl, nil
+}
+
+// MustRepoLogger 与 GetRepoLogger 相同，但在 repo 不存在时直接 panic，
+// 便于在 init() 或 main() 中做一次性的级别配置。
+func MustRepoLogger(repo string) *RepoLogger {
+	rl, err := GetRepoLogger(repo)
+	if err != nil {
+		panic(err)
+	}
+
+	return rl
+}
+
+// Backend 返回该 repo 下所有 PackageLogger 共享的底层 Logger，用于 AddHook 等
+// SetOutput/SetFormatter 未覆盖到的场景。
+func (rl *RepoLogger) Backend() *Logger {
+	return rl.backend
+}
+
+// SetOutput 重新设置该 repo 下所有 PackageLogger 共享的输出目标。
+func (rl *RepoLogger) SetOutput(w io.Writer) {
+	rl.backend.SetOutput(w)
+}
+
+// SetFormatter 重新设置该 repo 下所有 PackageLogger 共享的 Formatter。
+func (rl *RepoLogger) SetFormatter(formatter Formatter) {
+	rl.backend.SetFormatter(formatter)
+}
+
+// SetLogLevel 按包名批量设置级别。"*" 作为通配符，对 levels 中未逐一列出的包生效。
+func (rl *RepoLogger) SetLogLevel(levels map[string]int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	def, hasDefault := levels["*"]
+	for name, pl := range rl.packages {
+		if lv, ok := levels[name]; ok {
+			pl.SetLevel(lv)
+		} else if hasDefault {
+			pl.SetLevel(def)
+		}
+	}
+}
+
+// ParseLogLevelConfig 解析形如 "pkg=debug,other=warn,*=info" 的配置字符串，
+// 键为包名（"*" 表示默认级别），值取自 LevelText 支持的级别名。
+func ParseLogLevelConfig(config string) (map[string]int, error) {
+	levels := make(map[string]int)
+	if config == "" {
+		return levels, nil
+	}
+
+	for _, pair := range strings.Split(config, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("log: invalid log level config entry %q", pair)
+		}
+
+		name := strings.TrimSpace(kv[0])
+		levelText := strings.ToLower(strings.TrimSpace(kv[1]))
+
+		lv, ok := LevelText[levelText]
+		if !ok {
+			return nil, fmt.Errorf("log: unknown log level %q for %q", levelText, name)
+		}
+
+		levels[name] = lv
+	}
+
+	return levels, nil
+}
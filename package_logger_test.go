@@ -0,0 +1,71 @@
+package log
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func helperPackageLoggerFatal(pl *PackageLogger) {
+	pl.Fatal("boom")
+}
+
+func helperPackageEntryFatal(pe *PackageEntry) {
+	pe.Fatal("boom")
+}
+
+// TestPackageLogger_Fatal_CallerReportsCallSite 在子进程里验证
+// PackageLogger.Fatal/PackageEntry.Fatal 和 PackageLogger.Debug 一样，把
+// function 字段归到真正调用 Fatal 的帧，而不是再往上一层的调用方。
+func TestPackageLogger_Fatal_CallerReportsCallSite(t *testing.T) {
+	if mode := os.Getenv("LOG_TEST_PKG_FATAL_CHILD"); mode != "" {
+		out, err := os.OpenFile(os.Getenv("LOG_TEST_PKG_FATAL_OUT"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			os.Exit(2)
+		}
+		defer out.Close()
+
+		pl := NewPackageLogger("pkgfatalrepo", "pkgfatalpkg")
+		rl := MustRepoLogger("pkgfatalrepo")
+		rl.SetOutput(out)
+		rl.SetFormatter(NewJSONFormatter())
+
+		switch mode {
+		case "logger":
+			helperPackageLoggerFatal(pl)
+		case "entry":
+			helperPackageEntryFatal(pl.WithField("k", "v"))
+		}
+		return
+	}
+
+	cases := []string{"logger", "entry"}
+	for _, mode := range cases {
+		t.Run(mode, func(t *testing.T) {
+			outPath := t.TempDir() + "/pkg-fatal.log"
+
+			cmd := exec.Command(os.Args[0], "-test.run", "TestPackageLogger_Fatal_CallerReportsCallSite")
+			cmd.Env = append(os.Environ(), "LOG_TEST_PKG_FATAL_CHILD="+mode, "LOG_TEST_PKG_FATAL_OUT="+outPath)
+
+			err := cmd.Run()
+			if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+				t.Fatalf("expected child to exit with status 1, got err=%v", err)
+			}
+
+			b, err := os.ReadFile(outPath)
+			if err != nil {
+				t.Fatalf("read logger output: %v", err)
+			}
+
+			wantFunction := "helperPackageLoggerFatal"
+			if mode == "entry" {
+				wantFunction = "helperPackageEntryFatal"
+			}
+
+			if !strings.Contains(string(b), `"function":"`+wantFunction+`"`) {
+				t.Fatalf("output missing expected call site %q: %q", wantFunction, string(b))
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RotatingFileOptions 配置 RotatingFileWriter 的滚动策略，零值表示对应限制不生效。
+type RotatingFileOptions struct {
+	// MaxSize 是单个日志文件的最大字节数，超过后触发按大小滚动；0 表示不按大小滚动。
+	MaxSize int64
+
+	// MaxAge 是备份文件的最大保留时长，超过的备份会在下次滚动时被清理；0 表示不清理。
+	MaxAge time.Duration
+
+	// MaxBackups 是保留的备份文件数量上限，0 表示不限制。
+	MaxBackups int
+
+	// Daily 为 true 时，即使未达到 MaxSize，也会在跨自然日时触发滚动。
+	Daily bool
+}
+
+// RotatingFileWriter 是一个支持按大小/按天滚动的 io.Writer，可直接通过
+// Logger.SetOutput(rw) 接入 Logger，替代 lumberjack 等第三方方案。它在 SIGHUP
+// 到来时会重新打开底层文件，便于与外部 logrotate 工具配合使用。
+type RotatingFileWriter struct {
+	mu sync.Mutex
+
+	filename string
+	opts     RotatingFileOptions
+
+	file      *os.File
+	size      int64
+	openedDay string
+
+	sighup chan os.Signal
+}
+
+// NewRotatingFile 打开（或创建）filename 并按 opts 配置的策略滚动。
+func NewRotatingFile(filename string, opts RotatingFileOptions) (*RotatingFileWriter, error) {
+	w := &RotatingFileWriter{
+		filename: filename,
+		opts:     opts,
+	}
+
+	if err := w.openCurrent(); err != nil {
+		return nil, err
+	}
+
+	w.watchSighup()
+
+	return w, nil
+}
+
+func (w *RotatingFileWriter) openCurrent() error {
+	f, err := os.OpenFile(w.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	w.file = f
+	w.size = info.Size()
+	w.openedDay = time.Now().Format("2006-01-02")
+
+	return nil
+}
+
+// Write 实现 io.Writer，在需要时先触发滚动，写入操作本身在 Logger 既有的
+// sync.Mutex 之外再受 RotatingFileWriter 自己的锁保护，可安全地被多个 Logger 共用。
+func (w *RotatingFileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.shouldRotateLocked() {
+		if err := w.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+
+	return n, err
+}
+
+func (w *RotatingFileWriter) shouldRotateLocked() bool {
+	if w.opts.MaxSize > 0 && w.size >= w.opts.MaxSize {
+		return true
+	}
+
+	if w.opts.Daily && time.Now().Format("2006-01-02") != w.openedDay {
+		return true
+	}
+
+	return false
+}
+
+func (w *RotatingFileWriter) rotateLocked() error {
+	if w.file != nil {
+		w.file.Close()
+	}
+
+	backupName := fmt.Sprintf("%s.%s", w.filename, time.Now().Format("20060102-150405.000000"))
+	if _, err := os.Stat(w.filename); err == nil {
+		if err := os.Rename(w.filename, backupName); err != nil {
+			return err
+		}
+	}
+
+	if err := w.openCurrent(); err != nil {
+		return err
+	}
+
+	w.pruneBackupsLocked()
+
+	return nil
+}
+
+// pruneBackupsLocked 按 MaxBackups/MaxAge 清理滚动产生的历史文件，调用方必须持有 w.mu。
+func (w *RotatingFileWriter) pruneBackupsLocked() {
+	if w.opts.MaxBackups <= 0 && w.opts.MaxAge <= 0 {
+		return
+	}
+
+	matches, err := filepath.Glob(w.filename + ".*")
+	if err != nil {
+		return
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(matches)))
+
+	now := time.Now()
+	for i, name := range matches {
+		remove := false
+		if w.opts.MaxBackups > 0 && i >= w.opts.MaxBackups {
+			remove = true
+		}
+
+		if !remove && w.opts.MaxAge > 0 {
+			if info, err := os.Stat(name); err == nil && now.Sub(info.ModTime()) > w.opts.MaxAge {
+				remove = true
+			}
+		}
+
+		if remove {
+			os.Remove(name)
+		}
+	}
+}
+
+// Reopen 关闭并重新打开底层文件，不做滚动改名；供外部 logrotate 在原地替换了
+// 文件之后调用，也会在收到 SIGHUP 时自动触发。
+func (w *RotatingFileWriter) Reopen() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file != nil {
+		w.file.Close()
+	}
+
+	return w.openCurrent()
+}
+
+// Close 停止监听重新打开信号（如果当前平台支持）并关闭底层文件。
+func (w *RotatingFileWriter) Close() error {
+	w.stopWatchingReopenSignal()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+
+	return w.file.Close()
+}
@@ -0,0 +1,108 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestRotatingFileWriter_RotatesOnMaxSize 验证写入超过 MaxSize 后会触发滚动，
+// 原文件被改名为备份，当前文件从头开始计数。
+func TestRotatingFileWriter_RotatesOnMaxSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	w, err := NewRotatingFile(path, RotatingFileOptions{MaxSize: 10})
+	if err != nil {
+		t.Fatalf("NewRotatingFile: %v", err)
+	}
+	defer w.Close()
+
+	if _, err := w.Write([]byte("0123456789")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	// 已达到 MaxSize，这次写入应先触发滚动，再写入新文件。
+	if _, err := w.Write([]byte("x")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+
+	if len(matches) != 1 {
+		t.Fatalf("expected exactly one backup file, got %v", matches)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat current file: %v", err)
+	}
+
+	if info.Size() != 1 {
+		t.Fatalf("current file size = %d, want 1 (only the post-rotation write)", info.Size())
+	}
+}
+
+// TestRotatingFileWriter_PrunesMaxBackups 验证 MaxBackups 限制了保留的备份文件数量。
+func TestRotatingFileWriter_PrunesMaxBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	w, err := NewRotatingFile(path, RotatingFileOptions{MaxSize: 1, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("NewRotatingFile: %v", err)
+	}
+	defer w.Close()
+
+	for i := 0; i < 5; i++ {
+		if _, err := w.Write([]byte("xx")); err != nil {
+			t.Fatalf("Write %d: %v", i, err)
+		}
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+
+	if len(matches) > 2 {
+		t.Fatalf("expected at most 2 backups, got %d: %v", len(matches), matches)
+	}
+}
+
+// TestRotatingFileWriter_Reopen 验证 Reopen 在文件被外部移走后重新创建它，不做改名。
+func TestRotatingFileWriter_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	w, err := NewRotatingFile(path, RotatingFileOptions{})
+	if err != nil {
+		t.Fatalf("NewRotatingFile: %v", err)
+	}
+	defer w.Close()
+
+	if err := os.Rename(path, path+".moved"); err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+
+	if err := w.Reopen(); err != nil {
+		t.Fatalf("Reopen: %v", err)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected %s to exist after Reopen: %v", path, err)
+	}
+
+	if _, err := w.Write([]byte("hello")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	if string(b) != "hello" {
+		t.Fatalf("content = %q, want hello", string(b))
+	}
+}
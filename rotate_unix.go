@@ -0,0 +1,27 @@
+//go:build !windows
+
+package log
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchSighup 在收到 SIGHUP 时重新打开底层文件，便于与外部 logrotate 工具配合：
+// logrotate 把旧文件移走后发一个 SIGHUP，这里据此重新 open 同名路径。
+func (w *RotatingFileWriter) watchSighup() {
+	w.sighup = make(chan os.Signal, 1)
+	signal.Notify(w.sighup, syscall.SIGHUP)
+
+	go func() {
+		for range w.sighup {
+			w.Reopen()
+		}
+	}()
+}
+
+func (w *RotatingFileWriter) stopWatchingReopenSignal() {
+	signal.Stop(w.sighup)
+	close(w.sighup)
+}
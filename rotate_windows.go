@@ -0,0 +1,9 @@
+//go:build windows
+
+package log
+
+// watchSighup 是无操作实现：Windows 没有 SIGHUP，外部工具想要重新打开文件时
+// 应直接调用 RotatingFileWriter.Reopen。
+func (w *RotatingFileWriter) watchSighup() {}
+
+func (w *RotatingFileWriter) stopWatchingReopenSignal() {}
@@ -0,0 +1,8 @@
+package log
+
+// SyslogPriority 镜像了 log/syslog.Priority 的取值（facility|severity），
+// 但在本包里独立定义，这样 NewSyslogHook 在 unix 和 windows 上的签名保持一致，
+// 不会因为 log/syslog 只在 unix 上可用而在构建标签背后悄悄换成 int。log/syslog
+// 包里的 syslog.LOG_ERR 等常量是 syslog.Priority 类型，底层类型同为 int，
+// 需显式转换后传入，如 SyslogPriority(syslog.LOG_ERR)。
+type SyslogPriority int